@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"fmt"
+
+	"github.com/cilium/ebpf/btf"
+
+	"go.opentelemetry.io/auto/internal/pkg/inject"
+	"go.opentelemetry.io/auto/internal/pkg/process"
+	"go.opentelemetry.io/auto/internal/pkg/structfield"
+)
+
+// BTFStructFieldConst is a [Const] for a struct field offset resolved at
+// load time from BTF type information, instead of a pre-recorded offset
+// from the [inject] package. This lets a probe survive a Go stdlib or
+// library upgrade that has not yet been added to the offsets database.
+//
+// Unlike a kernel CO-RE relocation, this does not rely on the target Go
+// binary describing its own types: Go binaries do not emit self-describing
+// BTF the way C binaries compiled with -g and pahole's BTF generation do.
+// Instead, Spec must be supplied by the probe author, e.g. loaded from a
+// BTF blob vendored alongside the target library's source, generated ahead
+// of time for the versions the probe supports. If Spec is nil, or does not
+// describe the requested struct, InjectOption falls back to the same
+// recorded-offset lookup used by [StructFieldConst].
+type BTFStructFieldConst struct {
+	Key  string
+	Val  structfield.ID
+	Spec *btf.Spec
+}
+
+// InjectOption returns an [inject.Option] for the field offset. It first
+// tries to resolve the offset from c.Spec. If that fails, it falls back to
+// the recorded offset for the known module version.
+func (c BTFStructFieldConst) InjectOption(td *process.TargetDetails) (inject.Option, error) {
+	if c.Spec != nil {
+		if off, err := btfFieldOffset(c.Spec, c.Val); err == nil {
+			return inject.WithKeyValue(c.Key, off), nil
+		}
+	}
+
+	ver, ok := td.Libraries[c.Val.ModPath]
+	if !ok {
+		return nil, fmt.Errorf("unknown module version: %s", c.Val.ModPath)
+	}
+	return inject.WithOffset(c.Key, c.Val, ver), nil
+}
+
+// btfFieldOffset resolves the byte offset of id's struct field using spec,
+// without consulting the recorded offsets table.
+func btfFieldOffset(spec *btf.Spec, id structfield.ID) (uint64, error) {
+	var st *btf.Struct
+	iter := spec.Iterate()
+	for iter.Next() {
+		s, ok := iter.Type.(*btf.Struct)
+		if ok && s.Name == id.Struct {
+			st = s
+			break
+		}
+	}
+	if st == nil {
+		return 0, fmt.Errorf("struct %s not found in BTF", id.Struct)
+	}
+
+	for _, m := range st.Members {
+		if m.Name == id.Field {
+			return uint64(m.Offset.Bytes()), nil
+		}
+	}
+	return 0, fmt.Errorf("field %s not found in struct %s", id.Field, id.Struct)
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+
+	"go.opentelemetry.io/auto/internal/pkg/inject"
+	"go.opentelemetry.io/auto/internal/pkg/process"
+)
+
+// defaultCgroupRoot is the conventional mount point of the cgroup v2
+// hierarchy on a Linux host.
+const defaultCgroupRoot = "/sys/fs/cgroup"
+
+// containerScopeFormats are the cgroup v2 leaf-directory naming conventions
+// used by common container runtimes to embed a container ID. A bare
+// container ID is matched against every directory name under
+// defaultCgroupRoot, as is and in each of these formats at once; the first
+// matching directory in lexical walk order is used, whichever name it
+// matched. Other runtimes or custom cgroup layouts are not covered; pass an absolute
+// CgroupPath for those.
+var containerScopeFormats = []string{
+	"docker-%s.scope",
+	"cri-containerd-%s.scope",
+	"crio-%s.scope",
+	"libpod-%s.scope",
+}
+
+// ProcessFilter restricts a Probe to processes within a single cgroup v2
+// scope. It lets one auto-instrumentation process safely watch many
+// container workloads on the same node without their events crossing over,
+// as long as the probe's own eBPF program enforces the filter: see
+// [CgroupConst] for what this package actually provides towards that.
+type ProcessFilter struct {
+	// CgroupPath is either an absolute cgroup v2 path (e.g.
+	// "/sys/fs/cgroup/kubepods/besteffort/pod123/container456") or a
+	// container ID, which is resolved to its cgroup path under
+	// defaultCgroupRoot by matching the directory name exactly or against
+	// one of containerScopeFormats.
+	CgroupPath string
+}
+
+// resolve returns the absolute cgroup v2 path for f.CgroupPath, resolving a
+// bare container ID against defaultCgroupRoot if needed.
+func (f ProcessFilter) resolve() (string, error) {
+	if filepath.IsAbs(f.CgroupPath) {
+		return f.CgroupPath, nil
+	}
+
+	names := make(map[string]struct{}, len(containerScopeFormats)+1)
+	names[f.CgroupPath] = struct{}{}
+	for _, format := range containerScopeFormats {
+		names[fmt.Sprintf(format, f.CgroupPath)] = struct{}{}
+	}
+
+	var match string
+	err := filepath.WalkDir(defaultCgroupRoot, func(path string, d os.DirEntry, err error) error {
+		if err != nil || match != "" {
+			return err
+		}
+		if _, ok := names[filepath.Base(path)]; ok && d.IsDir() {
+			match = path
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("walk %s: %w", defaultCgroupRoot, err)
+	}
+	if match == "" {
+		return "", fmt.Errorf("no cgroup found for %q under %s", f.CgroupPath, defaultCgroupRoot)
+	}
+	return match, nil
+}
+
+// cgroupID returns the kernel cgroup id for path, as returned by
+// bpf_get_current_cgroup_id() for processes within it. The cgroup id is the
+// inode number of the cgroup's directory in the cgroupfs.
+func cgroupID(path string) (uint64, error) {
+	var stat unix.Stat_t
+	if err := unix.Stat(path, &stat); err != nil {
+		return 0, fmt.Errorf("stat cgroup %s: %w", path, err)
+	}
+	return stat.Ino, nil
+}
+
+// CgroupConst is a [Const] that injects the kernel cgroup id identified by a
+// [ProcessFilter] into an eBPF program as a constant, for the program to
+// compare against bpf_get_current_cgroup_id() and drop events from other
+// cgroups at the source, rather than relying on a uprobe attached globally
+// to the target's executable.
+//
+// CgroupConst only resolves and injects the id: it does not itself attach a
+// [cilium/ebpf/link] cgroup program or populate a BPF map filter. The
+// probe's own eBPF program is responsible for performing the
+// bpf_get_current_cgroup_id() comparison using the injected constant.
+type CgroupConst struct {
+	Filter ProcessFilter
+}
+
+// InjectOption returns the appropriately configured [inject.WithKeyValue]
+// carrying the resolved cgroup id for c.Filter.
+func (c CgroupConst) InjectOption(*process.TargetDetails) (inject.Option, error) {
+	path, err := c.Filter.resolve()
+	if err != nil {
+		return nil, fmt.Errorf("resolve cgroup for filter: %w", err)
+	}
+	id, err := cgroupID(path)
+	if err != nil {
+		return nil, err
+	}
+	return inject.WithKeyValue("target_cgroup_id", id), nil
+}
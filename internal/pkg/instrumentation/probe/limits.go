@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// defaultBufferPages is the number of per-CPU pages used for a perf or ring
+// buffer reader when no cgroup CPU limit is detected.
+const defaultBufferPages = 128
+
+// Limits describes the resource constraints detected for the calling
+// process's own cgroup. A Base uses it to derive sensibly-sized defaults
+// for its event pipeline instead of over-allocating relative to the
+// container's actual quota.
+type Limits struct {
+	// CPUQuota is the number of CPUs available to the cgroup, derived from
+	// cpu.max (cgroup v2) or cpu.cfs_quota_us/cpu.cfs_period_us (cgroup v1).
+	// It is 0 if no quota is set.
+	CPUQuota float64
+	// MemoryMax is the memory.max (cgroup v2) or memory.limit_in_bytes
+	// (cgroup v1) byte limit. It is 0 if unlimited or undetectable.
+	MemoryMax int64
+}
+
+// DetectLimits reads the cgroup v2 cpu.max and memory.max files of the
+// hierarchy mounted at /sys/fs/cgroup, falling back to the cgroup v1
+// equivalents when a v2 file is absent or sets no limit, and logs what it
+// finds through logger. These are the calling process's own limits when it
+// runs in its own cgroup namespace, as in a container; /proc/self/cgroup is
+// not consulted.
+func DetectLimits(logger logr.Logger) Limits {
+	l := Limits{CPUQuota: readCPUQuota(), MemoryMax: readMemoryMax()}
+	logger.Info("detected cgroup resource limits", "cpuQuota", l.CPUQuota, "memoryMax", l.MemoryMax)
+	return l
+}
+
+// BufferPages returns a sensible number of per-CPU pages to allocate for a
+// perf or ring buffer reader, scaled down from defaultBufferPages when
+// CPUQuota is constrained relative to the host's CPU count.
+func (l Limits) BufferPages() int {
+	if l.CPUQuota <= 0 {
+		return defaultBufferPages
+	}
+	return scaleByRatio(defaultBufferPages, l.CPUQuota/float64(runtime.NumCPU()), 8)
+}
+
+// EventsChanCap returns a sensible capacity for a probe's outbound events
+// queue, scaled from defaultQueueCapacity by MemoryMax relative to a 512MiB
+// baseline.
+func (l Limits) EventsChanCap() int {
+	const baseline = 512 * 1024 * 1024
+	if l.MemoryMax <= 0 {
+		return defaultQueueCapacity
+	}
+	return scaleByRatio(defaultQueueCapacity, float64(l.MemoryMax)/baseline, 16)
+}
+
+// scaleByRatio scales base by ratio, rounding to the nearest integer and
+// never returning less than floor.
+func scaleByRatio(base int, ratio float64, floor int) int {
+	v := int(math.Round(float64(base) * ratio))
+	return max(v, floor)
+}
+
+// ApplyGoRuntimeHints sets GOMAXPROCS and GOMEMLIMIT from l, leaving either
+// alone if the user has already configured it through the corresponding
+// environment variable.
+func (l Limits) ApplyGoRuntimeHints(logger logr.Logger) {
+	if l.CPUQuota > 0 && os.Getenv("GOMAXPROCS") == "" {
+		n := int(math.Ceil(l.CPUQuota))
+		runtime.GOMAXPROCS(n)
+		logger.Info("set GOMAXPROCS from cgroup CPU quota", "GOMAXPROCS", n)
+	}
+	if l.MemoryMax > 0 && os.Getenv("GOMEMLIMIT") == "" {
+		debug.SetMemoryLimit(l.MemoryMax)
+		logger.Info("set GOMEMLIMIT from cgroup memory limit", "GOMEMLIMIT", l.MemoryMax)
+	}
+}
+
+// cgroupV2Root is the conventional mount point of the cgroup v2 hierarchy
+// on a Linux host.
+const cgroupV2Root = "/sys/fs/cgroup"
+
+// cgroupV1CPURoot and cgroupV1MemoryRoot are the conventional mount points
+// of the cgroup v1 cpu and memory controllers on a Linux host.
+const (
+	cgroupV1CPURoot    = "/sys/fs/cgroup/cpu"
+	cgroupV1MemoryRoot = "/sys/fs/cgroup/memory"
+)
+
+func readCPUQuota() float64 {
+	if v, ok := parseCPUMaxFile(cgroupV2Root + "/cpu.max"); ok {
+		return v
+	}
+	if v, ok := parseCFSQuota(cgroupV1CPURoot+"/cpu.cfs_quota_us", cgroupV1CPURoot+"/cpu.cfs_period_us"); ok {
+		return v
+	}
+	return 0
+}
+
+func readMemoryMax() int64 {
+	if v, ok := parseMemoryMaxFile(cgroupV2Root + "/memory.max"); ok {
+		return v
+	}
+	// cgroup v1 reports no limit as a value close to math.MaxInt64.
+	if v, err := parseFloatFile(cgroupV1MemoryRoot + "/memory.limit_in_bytes"); err == nil && v < 1<<62 {
+		return int64(v)
+	}
+	return 0
+}
+
+// parseCPUMaxFile parses a cgroup v2 cpu.max file at path, formatted as
+// either "max" (no quota) or "<quota> <period>" in microseconds. It returns
+// the quota as a fraction of CPUs, and false if path doesn't exist or isn't
+// in the expected format.
+func parseCPUMaxFile(path string) (float64, bool) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+
+	fields := strings.Fields(strings.TrimSpace(string(b)))
+	if len(fields) != 2 || fields[0] == "max" {
+		return 0, false
+	}
+	quota, qErr := strconv.ParseFloat(fields[0], 64)
+	period, pErr := strconv.ParseFloat(fields[1], 64)
+	if qErr != nil || pErr != nil || period <= 0 {
+		return 0, false
+	}
+	return quota / period, true
+}
+
+// parseCFSQuota parses the cgroup v1 cpu.cfs_quota_us and cpu.cfs_period_us
+// files at quotaPath and periodPath, returning the quota as a fraction of
+// CPUs, and false if either file is missing, unparsable, or the quota is
+// unset (-1).
+func parseCFSQuota(quotaPath, periodPath string) (float64, bool) {
+	quota, qErr := parseFloatFile(quotaPath)
+	period, pErr := parseFloatFile(periodPath)
+	if qErr != nil || pErr != nil || quota <= 0 || period <= 0 {
+		return 0, false
+	}
+	return quota / period, true
+}
+
+// parseMemoryMaxFile parses a cgroup v2 memory.max file at path, formatted
+// as either "max" (unlimited) or a byte count. It returns false if path
+// doesn't exist, is "max", or isn't a valid integer.
+func parseMemoryMaxFile(path string) (int64, bool) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+
+	s := strings.TrimSpace(string(b))
+	if s == "max" {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+func parseFloatFile(path string) (float64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(strings.TrimSpace(string(b)), 64)
+}
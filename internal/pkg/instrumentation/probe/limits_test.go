@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestParseCPUMaxFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		want    float64
+		wantOK  bool
+	}{
+		{name: "unlimited", content: "max 100000\n", wantOK: false},
+		{name: "two_cpus", content: "200000 100000\n", want: 2, wantOK: true},
+		{name: "half_cpu", content: "50000 100000\n", want: 0.5, wantOK: true},
+		{name: "malformed", content: "not-a-number 100000\n", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, tt.name, tt.content)
+			got, ok := parseCPUMaxFile(path)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, ok := parseCPUMaxFile(filepath.Join(dir, "missing")); ok {
+		t.Fatal("expected ok = false for a missing file")
+	}
+}
+
+func TestParseCFSQuota(t *testing.T) {
+	dir := t.TempDir()
+	quotaPath := writeFile(t, dir, "cpu.cfs_quota_us", "150000\n")
+	periodPath := writeFile(t, dir, "cpu.cfs_period_us", "100000\n")
+
+	got, ok := parseCFSQuota(quotaPath, periodPath)
+	if !ok || got != 1.5 {
+		t.Fatalf("got (%v, %v), want (1.5, true)", got, ok)
+	}
+
+	unsetQuotaPath := writeFile(t, dir, "cpu.cfs_quota_us_unset", "-1\n")
+	if _, ok := parseCFSQuota(unsetQuotaPath, periodPath); ok {
+		t.Fatal("expected ok = false for an unset (-1) quota")
+	}
+}
+
+func TestParseMemoryMaxFile(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		want    int64
+		wantOK  bool
+	}{
+		{name: "unlimited", content: "max\n", wantOK: false},
+		{name: "bytes", content: "536870912\n", want: 536870912, wantOK: true},
+		{name: "malformed", content: "not-a-number\n", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, tt.name, tt.content)
+			got, ok := parseMemoryMaxFile(path)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScaleByRatio(t *testing.T) {
+	tests := []struct {
+		name  string
+		base  int
+		ratio float64
+		floor int
+		want  int
+	}{
+		{name: "half", base: 128, ratio: 0.5, floor: 8, want: 64},
+		{name: "full", base: 128, ratio: 1, floor: 8, want: 128},
+		{name: "floored", base: 128, ratio: 0.01, floor: 8, want: 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scaleByRatio(tt.base, tt.ratio, tt.floor)
+			if got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitsBufferPagesAndEventsChanCapDefaults(t *testing.T) {
+	var l Limits
+	if got := l.BufferPages(); got != defaultBufferPages {
+		t.Fatalf("BufferPages() = %d, want %d", got, defaultBufferPages)
+	}
+	if got := l.EventsChanCap(); got != defaultQueueCapacity {
+		t.Fatalf("EventsChanCap() = %d, want %d", got, defaultQueueCapacity)
+	}
+}
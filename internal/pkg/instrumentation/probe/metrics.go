@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// meterName is the instrumentation scope used for the self-metrics a Probe
+// reports about its own operation.
+const meterName = "go.opentelemetry.io/auto/internal/pkg/instrumentation/probe"
+
+// selfMetrics are the internal OTel metrics a Probe reports about its own
+// event pipeline: how many events it has read, how many it has had to
+// drop, and how deep its outbound queue currently is.
+type selfMetrics struct {
+	eventsRead metric.Int64Counter
+	eventsLost metric.Int64Counter
+	attrs      metric.MeasurementOption
+}
+
+// newSelfMetrics registers the self-metric instruments for a probe named
+// name, reporting from the global MeterProvider. A zero value is usable and
+// silently drops measurements if instrument creation fails.
+func newSelfMetrics(name string) *selfMetrics {
+	meter := otel.GetMeterProvider().Meter(meterName)
+	attrs := metric.WithAttributes(attribute.String("probe", name))
+
+	sm := &selfMetrics{attrs: attrs}
+	// Errors here only mean the self-metrics are unavailable; they must
+	// never prevent the probe itself from running.
+	sm.eventsRead, _ = meter.Int64Counter(
+		"otel.auto.probe.events_read_total",
+		metric.WithDescription("Number of events read from the probe's eBPF event reader."),
+	)
+	sm.eventsLost, _ = meter.Int64Counter(
+		"otel.auto.probe.events_lost_total",
+		metric.WithDescription("Number of events lost before they could be read, or evicted from the probe's full outbound queue."),
+	)
+	return sm
+}
+
+func (sm *selfMetrics) addRead(ctx context.Context, n int64) {
+	if sm == nil || sm.eventsRead == nil {
+		return
+	}
+	sm.eventsRead.Add(ctx, n, sm.attrs)
+}
+
+func (sm *selfMetrics) addLost(ctx context.Context, n int64) {
+	if sm == nil || sm.eventsLost == nil {
+		return
+	}
+	sm.eventsLost.Add(ctx, n, sm.attrs)
+}
+
+// registerQueueDepth registers an asynchronous gauge that reports depth() as
+// the probe's outbound queue_depth. It is a no-op if instrument creation
+// fails.
+func (sm *selfMetrics) registerQueueDepth(name string, depth func() int) {
+	if sm == nil {
+		return
+	}
+	meter := otel.GetMeterProvider().Meter(meterName)
+	_, _ = meter.Int64ObservableGauge(
+		"otel.auto.probe.queue_depth",
+		metric.WithDescription("Number of events buffered in the probe's outbound queue, awaiting export."),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(depth()), sm.attrs)
+			return nil
+		}),
+	)
+}
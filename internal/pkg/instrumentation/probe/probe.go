@@ -17,15 +17,18 @@ package probe
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
 	"sort"
+	"sync"
 
 	"github.com/cilium/ebpf"
 	"github.com/cilium/ebpf/link"
 	"github.com/cilium/ebpf/perf"
+	"github.com/cilium/ebpf/ringbuf"
 	"github.com/go-logr/logr"
 
 	"go.opentelemetry.io/auto/internal/pkg/inject"
@@ -49,12 +52,17 @@ type Probe interface {
 	Load(*link.Executable, *process.TargetDetails) error
 
 	// Run runs the events processing loop.
-	Run(eventsChan chan<- *Event)
+	Run(eventsChan chan<- Signal)
 
 	// Close stops the Probe.
 	Close()
 }
 
+// applyGoRuntimeHintsOnce ensures GOMAXPROCS/GOMEMLIMIT are derived from the
+// detected cgroup limits at most once per process, regardless of how many
+// Base instances call Load.
+var applyGoRuntimeHintsOnce sync.Once
+
 // Base is a base implementation of [Probe].
 //
 // This type can be returned by instrumentation directly. Instrumentation can
@@ -71,20 +79,50 @@ type Base[BPFObj any, BPFEvent any] struct {
 	// Consts are the constants that need to be injected into the eBPF program
 	// that is run by this Probe.
 	Consts []Const
-	// Uprobes is a mapping from runtime symbols to a UprobeFunc. These define
-	// the eBPF program triggers that need to setup for this Probe.
-	Uprobes map[string]UprobeFunc[BPFObj]
-
+	// ProcessFilter, if set, restricts this Probe to the single cgroup v2
+	// scope it identifies. A CgroupConst for the filter is injected
+	// automatically in addition to Consts. Events from processes outside the
+	// scope are dropped in-kernel only if the probe's eBPF program compares
+	// the injected id against bpf_get_current_cgroup_id(); Base attaches no
+	// cgroup program or map filter of its own.
+	ProcessFilter *ProcessFilter
+	// Uprobes declares the eBPF programs that need to be attached for this
+	// Probe. Multiple Uprobes may share a Symbol, e.g. to attach distinct
+	// entry and return programs for the same function.
+	Uprobes []Uprobe[BPFObj]
+
+	// ReaderKind selects whether ReaderFn or RingBufReaderFn is used to read
+	// events out of the eBPF program. It defaults to ReaderKindPerf.
+	ReaderKind ReaderKind
 	// ReaderFn is a creation function for a perf.Reader based on the passed
-	// BPFObj related to the probe.
+	// BPFObj related to the probe. It is used when ReaderKind is
+	// ReaderKindPerf.
 	ReaderFn func(BPFObj) (*perf.Reader, error)
+	// RingBufReaderFn is a creation function for a ringbuf.Reader based on the
+	// passed BPFObj related to the probe. It is used when ReaderKind is
+	// ReaderKindRingBuf.
+	RingBufReaderFn func(BPFObj) (*ringbuf.Reader, error)
 	// SpecFn is a creation function for an eBPF CollectionSpec related to the
 	// probe.
 	SpecFn func() (*ebpf.CollectionSpec, error)
-	// ProcessFn processes probe events into a uniform Event type.
-	ProcessFn func(*BPFEvent) *Event
-
-	reader  *perf.Reader
+	// ProcessFn processes probe events into a uniform Signal type.
+	ProcessFn func(*BPFEvent) Signal
+
+	// QueueCap is the capacity of the bounded outbound queue Run uses to
+	// buffer processed Signals before they are sent to the events channel.
+	// Once full, the oldest queued Signal is dropped to make room for the
+	// newest one, so a stuck consumer cannot block event collection. A
+	// non-positive value is derived from Limits, detected from the cgroup
+	// CPU/memory limits applied to this process.
+	QueueCap int
+	// Limits are the cgroup CPU/memory limits Load detects for this
+	// process, used to derive QueueCap when it is left unset and available
+	// for ReaderFn/RingBufReaderFn to size their own buffers accordingly.
+	Limits Limits
+
+	reader  eventReader
+	queue   *dropQueue
+	metrics *selfMetrics
 	closers []io.Closer
 }
 
@@ -95,9 +133,14 @@ func (i *Base[BPFObj, BPFEvent]) LibraryName() string {
 
 // FuncNames returns the fully-qualified function names that are instrumented.
 func (i *Base[BPFObj, BPFEvent]) FuncNames() []string {
+	seen := make(map[string]struct{}, len(i.Uprobes))
 	symbols := make([]string, 0, len(i.Uprobes))
-	for s := range i.Uprobes {
-		symbols = append(symbols, s)
+	for _, u := range i.Uprobes {
+		if _, ok := seen[u.Symbol]; ok {
+			continue
+		}
+		seen[u.Symbol] = struct{}{}
+		symbols = append(symbols, u.Symbol)
 	}
 
 	sort.Strings(symbols)
@@ -107,6 +150,12 @@ func (i *Base[BPFObj, BPFEvent]) FuncNames() []string {
 
 // Load loads all instrumentation offsets.
 func (i *Base[BPFObj, BPFEvent]) Load(exec *link.Executable, td *process.TargetDetails) error {
+	i.Limits = DetectLimits(i.Logger)
+	applyGoRuntimeHintsOnce.Do(func() { i.Limits.ApplyGoRuntimeHints(i.Logger) })
+	if i.QueueCap <= 0 {
+		i.QueueCap = i.Limits.EventsChanCap()
+	}
+
 	spec, err := i.SpecFn()
 	if err != nil {
 		return err
@@ -122,17 +171,36 @@ func (i *Base[BPFObj, BPFEvent]) Load(exec *link.Executable, td *process.TargetD
 		return err
 	}
 
-	i.reader, err = i.ReaderFn(*obj)
-	if err != nil {
-		return err
+	switch i.ReaderKind {
+	case ReaderKindRingBuf:
+		r, err := i.RingBufReaderFn(*obj)
+		if err != nil {
+			return err
+		}
+		i.reader = newRingBufEventReader(r)
+	default:
+		r, err := i.ReaderFn(*obj)
+		if err != nil {
+			return err
+		}
+		i.reader = newPerfEventReader(r)
 	}
 	i.closers = append(i.closers, i.reader)
 
+	i.queue = newDropQueue(i.QueueCap)
+	i.metrics = newSelfMetrics(i.Name)
+	i.metrics.registerQueueDepth(i.Name, i.queue.depth)
+
 	return nil
 }
 
 func (i *Base[BPFObj, BPFEvent]) injectConsts(td *process.TargetDetails, spec *ebpf.CollectionSpec) error {
-	opts, err := consts(i.Consts).injectOpts(td)
+	cs := i.Consts
+	if i.ProcessFilter != nil {
+		cs = append([]Const{CgroupConst{Filter: *i.ProcessFilter}}, cs...)
+	}
+
+	opts, err := consts(cs).injectOpts(td)
 	if err != nil {
 		return err
 	}
@@ -155,9 +223,13 @@ func (i *Base[BPFObj, BPFEvent]) buildObj(exec *link.Executable, td *process.Tar
 		return nil, err
 	}
 
-	for symb, f := range i.Uprobes {
-		links, err := f(symb, exec, td, obj)
+	for _, u := range i.Uprobes {
+		links, err := u.Fn(u, exec, td, obj)
 		if err != nil {
+			if u.Optional && errors.Is(err, ErrSymbolNotFound) {
+				i.Logger.Info("optional symbol not found, skipping", "symbol", u.Symbol)
+				continue
+			}
 			return nil, err
 		}
 		for _, l := range links {
@@ -168,34 +240,58 @@ func (i *Base[BPFObj, BPFEvent]) buildObj(exec *link.Executable, td *process.Tar
 	return obj, nil
 }
 
-// Run runs the events processing loop.
-func (i *Base[BPFObj, BPFEvent]) Run(dest chan<- *Event) {
+// Run runs the events processing loop. Reading from the eBPF event source
+// and forwarding to dest happen concurrently through a bounded, dropping
+// queue so a slow consumer on dest cannot stall event collection.
+func (i *Base[BPFObj, BPFEvent]) Run(dest chan<- Signal) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			s, ok := i.queue.pop()
+			if !ok {
+				return
+			}
+			dest <- s
+		}
+	}()
+
+	ctx := context.Background()
 	for {
-		record, err := i.reader.Read()
+		rec, err := i.reader.Read()
 		if err != nil {
-			if errors.Is(err, perf.ErrClosed) {
-				return
+			if errors.Is(err, errReaderClosed) {
+				break
 			}
-			i.Logger.Error(err, "error reading from perf reader")
+			i.Logger.Error(err, "error reading from event reader")
 			continue
 		}
 
-		if record.LostSamples != 0 {
-			i.Logger.Info("perf event ring buffer full", "dropped", record.LostSamples)
+		if rec.LostSamples != 0 {
+			i.metrics.addLost(ctx, int64(rec.LostSamples))
+			i.Logger.Info("event ring buffer full", "dropped", rec.LostSamples)
 			continue
 		}
+		i.metrics.addRead(ctx, 1)
 
-		e, err := i.processRecord(record)
+		s, err := i.processRecord(rec)
 		if err != nil {
-			i.Logger.Error(err, "failed to process perf record")
+			i.Logger.Error(err, "failed to process event record")
+			continue
 		}
 
-		dest <- e
+		if dropped := i.queue.push(s); dropped {
+			i.metrics.addLost(ctx, 1)
+			i.Logger.Info("events queue full, dropped oldest event", "probe", i.Name)
+		}
 	}
+
+	i.queue.close()
+	<-done
 }
 
-func (i *Base[BPFObj, BPFEvent]) processRecord(record perf.Record) (*Event, error) {
-	buf := bytes.NewBuffer(record.RawSample)
+func (i *Base[BPFObj, BPFEvent]) processRecord(rec record) (Signal, error) {
+	buf := bytes.NewBuffer(rec.RawSample)
 
 	var event BPFEvent
 	err := binary.Read(buf, binary.LittleEndian, &event)
@@ -216,17 +312,6 @@ func (i *Base[BPFObj, BPFEvent]) Close() {
 	}
 }
 
-// UprobeFunc is a function that will attach a eBPF program to a perf event
-// that fires when the given symbol starts executing in exec.
-//
-// It is expected the symbol belongs to are shared library and its offset can
-// be determined using target.
-//
-// Losing the reference to the resulting Link (up) will close the Uprobe and
-// prevent further execution of prog. The Link must be Closed during program
-// shutdown to avoid leaking system resources.
-type UprobeFunc[BPFObj any] func(symbol string, exec *link.Executable, target *process.TargetDetails, obj *BPFObj) ([]link.Link, error)
-
 // Const is an constant that needs to be injected into an eBPF program.
 type Const interface {
 	// InjectOption returns the inject.Option to run for the Const when running
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import "sync"
+
+// defaultQueueCapacity is the number of Signals a dropQueue buffers when
+// Base.QueueCap is left unset.
+const defaultQueueCapacity = 128
+
+// dropQueue is a fixed-capacity FIFO ring buffer of Signals. Pushing onto a
+// full queue evicts the oldest Signal instead of blocking the caller, so a
+// stuck downstream consumer drops events rather than stalling the probe
+// that is producing them. The backing array is allocated once and reused
+// in place, so steady-state push/pop does not allocate or retain evicted
+// Signals beyond their eviction.
+type dropQueue struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	buf  []Signal
+	head int // index of the oldest buffered Signal
+	size int // number of Signals currently buffered
+	cap  int
+
+	closed  bool
+	dropped uint64
+}
+
+// newDropQueue returns a dropQueue that buffers up to cap Signals. A
+// non-positive cap is replaced with defaultQueueCapacity.
+func newDropQueue(cap int) *dropQueue {
+	if cap <= 0 {
+		cap = defaultQueueCapacity
+	}
+	q := &dropQueue{buf: make([]Signal, cap), cap: cap}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// push appends s to the queue, evicting the oldest queued Signal first if
+// the queue is already at capacity. It reports whether a Signal was
+// dropped.
+func (q *dropQueue) push(s Signal) (dropped bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.size == q.cap {
+		// Full: evict the oldest Signal to make room, clearing its slot so
+		// the ring buffer doesn't keep it reachable.
+		q.buf[q.head] = nil
+		q.head = (q.head + 1) % q.cap
+		q.size--
+		q.dropped++
+		dropped = true
+	}
+
+	tail := (q.head + q.size) % q.cap
+	q.buf[tail] = s
+	q.size++
+	q.cond.Signal()
+	return dropped
+}
+
+// pop blocks until a Signal is available or the queue is closed. The second
+// return value is false only once the queue is closed and drained.
+func (q *dropQueue) pop() (Signal, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.size == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if q.size == 0 {
+		return nil, false
+	}
+
+	s := q.buf[q.head]
+	q.buf[q.head] = nil
+	q.head = (q.head + 1) % q.cap
+	q.size--
+	return s, true
+}
+
+// depth returns the number of Signals currently buffered.
+func (q *dropQueue) depth() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// close marks the queue closed, waking any goroutine blocked in pop.
+func (q *dropQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.cond.Broadcast()
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import "testing"
+
+func TestDropQueuePushPastCapacityDropsOldest(t *testing.T) {
+	q := newDropQueue(3)
+
+	for i := 0; i < 3; i++ {
+		if dropped := q.push(SpanEvent{Name: "a"}); dropped {
+			t.Fatalf("push %d: unexpected drop before reaching capacity", i)
+		}
+	}
+	if got := q.depth(); got != 3 {
+		t.Fatalf("depth() = %d, want 3", got)
+	}
+
+	if dropped := q.push(SpanEvent{Name: "overflow"}); !dropped {
+		t.Fatal("push past capacity: expected dropped = true")
+	}
+	if got := q.depth(); got != 3 {
+		t.Fatalf("depth() after overflow = %d, want 3", got)
+	}
+
+	// The 3 buffered signals should now be: the two original "a"s, followed
+	// by "overflow" — the first "a" was evicted to make room.
+	for i := 0; i < 2; i++ {
+		s, ok := q.pop()
+		if !ok {
+			t.Fatalf("pop %d: queue unexpectedly empty", i)
+		}
+		if s.(SpanEvent).Name != "a" {
+			t.Fatalf("pop %d: got %+v, want Name=a", i, s)
+		}
+	}
+	s, ok := q.pop()
+	if !ok || s.(SpanEvent).Name != "overflow" {
+		t.Fatalf("final pop: got (%+v, %v), want (overflow, true)", s, ok)
+	}
+}
+
+func TestDropQueueFIFOOrder(t *testing.T) {
+	q := newDropQueue(4)
+	for i := 0; i < 4; i++ {
+		q.push(MetricEvent{Name: string(rune('a' + i))})
+	}
+
+	for i := 0; i < 4; i++ {
+		s, ok := q.pop()
+		if !ok {
+			t.Fatalf("pop %d: queue unexpectedly empty", i)
+		}
+		want := string(rune('a' + i))
+		if s.(MetricEvent).Name != want {
+			t.Fatalf("pop %d: got %+v, want Name=%s", i, s, want)
+		}
+	}
+}
+
+func TestDropQueueCloseUnblocksPop(t *testing.T) {
+	q := newDropQueue(1)
+	q.close()
+
+	if s, ok := q.pop(); ok || s != nil {
+		t.Fatalf("pop() on closed empty queue = (%+v, %v), want (nil, false)", s, ok)
+	}
+}
+
+func TestDropQueueWrapsAroundRingBuffer(t *testing.T) {
+	q := newDropQueue(2)
+
+	q.push(SpanEvent{Name: "1"})
+	q.push(SpanEvent{Name: "2"})
+	if _, ok := q.pop(); !ok {
+		t.Fatal("pop: queue unexpectedly empty")
+	}
+	// head has now advanced past index 0; this push wraps the tail back to
+	// index 0, exercising the ring buffer's modular arithmetic.
+	q.push(SpanEvent{Name: "3"})
+
+	s, ok := q.pop()
+	if !ok || s.(SpanEvent).Name != "2" {
+		t.Fatalf("got (%+v, %v), want (2, true)", s, ok)
+	}
+	s, ok = q.pop()
+	if !ok || s.(SpanEvent).Name != "3" {
+		t.Fatalf("got (%+v, %v), want (3, true)", s, ok)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"errors"
+	"io"
+
+	"github.com/cilium/ebpf/perf"
+	"github.com/cilium/ebpf/ringbuf"
+)
+
+// ReaderKind selects the eBPF event-reader implementation a Probe uses to
+// receive events from its kernel-side program.
+type ReaderKind uint8
+
+const (
+	// ReaderKindPerf reads events from a BPF perf event array using
+	// [perf.Reader]. This is the default.
+	ReaderKindPerf ReaderKind = iota
+	// ReaderKindRingBuf reads events from a BPF ring buffer using
+	// [ringbuf.Reader]. Ring buffers avoid the per-CPU overhead of perf event
+	// arrays and are the preferred choice for new probes.
+	ReaderKindRingBuf
+)
+
+// record is a single raw event read from an [eventReader], unified across
+// the perf and ring buffer backends.
+type record struct {
+	RawSample   []byte
+	LostSamples uint64
+}
+
+// eventReader is the minimal interface [Base] needs from a perf or ring
+// buffer reader.
+type eventReader interface {
+	io.Closer
+	Read() (record, error)
+}
+
+// errReaderClosed is returned by an [eventReader] once it has been closed.
+var errReaderClosed = errors.New("event reader closed")
+
+type perfEventReader struct{ r *perf.Reader }
+
+// newPerfEventReader wraps r so it can be used as an [eventReader].
+func newPerfEventReader(r *perf.Reader) eventReader { return &perfEventReader{r: r} }
+
+func (p *perfEventReader) Read() (record, error) {
+	rec, err := p.r.Read()
+	if err != nil {
+		if errors.Is(err, perf.ErrClosed) {
+			return record{}, errReaderClosed
+		}
+		return record{}, err
+	}
+	return record{RawSample: rec.RawSample, LostSamples: rec.LostSamples}, nil
+}
+
+func (p *perfEventReader) Close() error { return p.r.Close() }
+
+type ringBufEventReader struct{ r *ringbuf.Reader }
+
+// newRingBufEventReader wraps r so it can be used as an [eventReader].
+func newRingBufEventReader(r *ringbuf.Reader) eventReader { return &ringBufEventReader{r: r} }
+
+func (p *ringBufEventReader) Read() (record, error) {
+	rec, err := p.r.Read()
+	if err != nil {
+		if errors.Is(err, ringbuf.ErrClosed) {
+			return record{}, errReaderClosed
+		}
+		return record{}, err
+	}
+	// A ring buffer reports no lost-sample count to the reader: when the ring
+	// is full, the eBPF program's reserve or output call fails and the event
+	// is dropped there, unseen here. LostSamples is therefore always 0.
+	return record{RawSample: rec.RawSample}, nil
+}
+
+func (p *ringBufEventReader) Close() error { return p.r.Close() }
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Signal is a telemetry signal emitted by a Probe. It is implemented by
+// SpanEvent, MetricEvent, and LogEvent so a single Probe can report traces,
+// metrics, and logs through the same events channel instead of forcing every
+// probe to look like a tracer.
+type Signal interface {
+	// private prevents external types from implementing Signal.
+	private()
+}
+
+// SpanEvent is a telemetry event that happens within an instrumented package.
+type SpanEvent struct {
+	Library           string
+	Name              string
+	Attributes        []attribute.KeyValue
+	Kind              trace.SpanKind
+	StartTime         int64
+	EndTime           int64
+	SpanContext       *trace.SpanContext
+	ParentSpanContext *trace.SpanContext
+}
+
+func (SpanEvent) private() {}
+
+// Event is an alias for SpanEvent, kept so source that still refers to the
+// pre-Signal Event type (e.g. a manager's export pipeline being migrated to
+// consume Signal) continues to compile against this package.
+//
+// Deprecated: use SpanEvent, or better, Signal, directly. This alias is
+// scaffolding for the Signal migration and will be removed once all known
+// consumers of the events channel have moved off of Run(chan<- *Event) and
+// ProcessFn func(*BPFEvent) *Event.
+type Event = SpanEvent
+
+// InstrumentKind identifies the kind of OTel metric instrument a MetricEvent
+// should be recorded with.
+type InstrumentKind int
+
+const (
+	// InstrumentKindCounter records a MetricEvent as a monotonic counter,
+	// e.g. a count of syscalls or allocations.
+	InstrumentKindCounter InstrumentKind = iota
+	// InstrumentKindHistogram records a MetricEvent as a histogram, e.g. a
+	// distribution of GC pause durations.
+	InstrumentKindHistogram
+)
+
+// MetricEvent is a telemetry measurement, such as a syscall count,
+// allocation size, or GC pause duration, produced by an instrumented
+// package.
+type MetricEvent struct {
+	Library    string
+	Name       string
+	Descr      string
+	Unit       string
+	Kind       InstrumentKind
+	Attributes []attribute.KeyValue
+	Value      float64
+	Time       int64
+}
+
+func (MetricEvent) private() {}
+
+// LogEvent is a telemetry log record produced by an instrumented package.
+type LogEvent struct {
+	Library    string
+	Body       string
+	Severity   int
+	Attributes []attribute.KeyValue
+	Time       int64
+}
+
+func (LogEvent) private() {}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"errors"
+
+	"github.com/cilium/ebpf/link"
+
+	"go.opentelemetry.io/auto/internal/pkg/process"
+)
+
+// ErrSymbolNotFound is returned by a [UprobeFunc] when the symbol it was
+// asked to attach to is not present in the target executable. A [Uprobe]
+// marked Optional is skipped, instead of failing Load, when its Fn returns
+// an error wrapping ErrSymbolNotFound.
+var ErrSymbolNotFound = errors.New("symbol not found in target executable")
+
+// UprobeAttachKind identifies where, within a symbol, a [Uprobe]'s Program
+// is attached.
+type UprobeAttachKind uint8
+
+const (
+	// UprobeAttachEntry attaches Program at the symbol's entry point. This
+	// is the default.
+	UprobeAttachEntry UprobeAttachKind = iota
+	// UprobeAttachReturn attaches Program at every return site of the
+	// symbol, found by scanning its body for RET instructions. This is
+	// required to reliably capture return values from Go functions, which
+	// commonly have more than one RET instruction.
+	UprobeAttachReturn
+	// UprobeAttachOffset attaches Program at a fixed byte Offset from the
+	// symbol's entry point, bypassing the usual prologue-based attach
+	// point. This is needed when Go's register ABI or inlining makes the
+	// prologue unusable.
+	UprobeAttachOffset
+)
+
+// Uprobe declaratively describes a single eBPF program to attach to a Go
+// symbol in a target executable.
+type Uprobe[BPFObj any] struct {
+	// Symbol is the fully-qualified function name to attach to.
+	Symbol string
+	// Kind is where, within Symbol, Program is attached.
+	Kind UprobeAttachKind
+	// Offset is the byte offset from the start of Symbol to attach at. It
+	// is only used when Kind is UprobeAttachOffset.
+	Offset uint64
+	// Program is the name of the eBPF program, within the probe's BPFObj,
+	// to attach.
+	Program string
+	// Optional marks that Load should skip this Uprobe, rather than fail,
+	// if Fn reports that Symbol is not present in the target executable.
+	Optional bool
+	// Fn attaches Program to exec according to Kind, Offset, and the other
+	// fields of this Uprobe.
+	Fn UprobeFunc[BPFObj]
+}
+
+// UprobeFunc is a function that attaches up's eBPF program to a perf event
+// that fires when up.Symbol executes in exec, according to up.Kind.
+//
+// It is expected the symbol belongs to exec and its offset can be
+// determined using target. If the symbol cannot be found, Fn should return
+// an error wrapping [ErrSymbolNotFound] so an Optional Uprobe can be
+// skipped instead of failing Load.
+//
+// Losing the reference to the resulting Links will close the Uprobe and
+// prevent further execution of its program. The Links must be Closed during
+// program shutdown to avoid leaking system resources.
+type UprobeFunc[BPFObj any] func(up Uprobe[BPFObj], exec *link.Executable, target *process.TargetDetails, obj *BPFObj) ([]link.Link, error)